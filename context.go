@@ -0,0 +1,65 @@
+package xhttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// DoCtx performs a request based on http.Request, bound to ctx.
+func (c *Client) DoCtx(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.Do(req.WithContext(ctx))
+}
+
+// SendCtx makes a request, bound to ctx.
+func (c *Client) SendCtx(ctx context.Context, request *Request) (*Response, error) {
+	return c.Send(request.WithContext(ctx))
+}
+
+// GETCtx makes a Get request, bound to ctx.
+func (c *Client) GETCtx(ctx context.Context, url string) (*Response, error) {
+	req := NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	return c.Send(req)
+}
+
+// POSTCtx makes a Post request, bound to ctx.
+func (c *Client) POSTCtx(ctx context.Context, url, contentType string, body []byte) (*Response, error) {
+	req := NewRequestWithContext(ctx, http.MethodPost, url, body)
+	req.Header.Set("Content-Type", contentType)
+
+	return c.Send(req)
+}
+
+// DownloadFileCtx downloads the file located at an url, bound to ctx, and
+// stores it in the given path.
+func (c *Client) DownloadFileCtx(ctx context.Context, url, filename string) error {
+	return c.Download(ctx, url, filename, nil)
+}
+
+// DoCtx makes a request based on http.Request, bound to ctx, using
+// DefaultClient.
+func DoCtx(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return DefaultClient.DoCtx(ctx, req)
+}
+
+// SendCtx makes a request based on Request, bound to ctx, using
+// DefaultClient.
+func SendCtx(ctx context.Context, req *Request) (*Response, error) {
+	return DefaultClient.SendCtx(ctx, req)
+}
+
+// GETCtx makes a GET request, bound to ctx, using DefaultClient.
+func GETCtx(ctx context.Context, url string) (*Response, error) {
+	return DefaultClient.GETCtx(ctx, url)
+}
+
+// POSTCtx makes a POST request, bound to ctx, using DefaultClient.
+func POSTCtx(ctx context.Context, url, contentType string, body []byte) (*Response, error) {
+	return DefaultClient.POSTCtx(ctx, url, contentType, body)
+}
+
+// DownloadFileCtx downloads the file located at an url, bound to ctx, and
+// stores it in the given path, using DefaultClient.
+func DownloadFileCtx(ctx context.Context, url, filename string) error {
+	return DefaultClient.DownloadFileCtx(ctx, url, filename)
+}
@@ -0,0 +1,118 @@
+package xhttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequest_WithContext(t *testing.T) {
+	type ctxKey string
+
+	req := NewRequest(http.MethodGet, "http://localhost", nil)
+
+	ctx := context.WithValue(context.Background(), ctxKey("k"), "v")
+	req2 := req.WithContext(ctx)
+
+	if req2.Context() != ctx {
+		t.Errorf("expected WithContext to set the new context")
+	}
+
+	if req.Context() == ctx {
+		t.Errorf("expected WithContext to leave the original Request unmodified")
+	}
+}
+
+func TestRequest_Context_Default(t *testing.T) {
+	req := &Request{BaseURL: "http://localhost", Method: http.MethodGet}
+
+	if req.Context() != context.Background() {
+		t.Errorf("expected a default background context")
+	}
+}
+
+func TestClient_SendCtx_Cancellation(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer func() {
+		close(unblock)
+		srv.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := NewClient()
+
+	req := NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := c.SendCtx(ctx, req); err == nil {
+		t.Error("expected an error from a cancelled context")
+	}
+}
+
+func TestClient_GETCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	resp, err := c.GETCtx(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("failed to GETCtx: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestClient_POSTCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	resp, err := c.POSTCtx(context.Background(), srv.URL, "application/octet-stream", []byte("payload"))
+	if err != nil {
+		t.Fatalf("failed to POSTCtx: %s", err)
+	}
+
+	if !bytes.Equal(resp.Body, []byte("payload")) {
+		t.Errorf("expected echoed body, got %s", resp.Body)
+	}
+}
+
+func TestClient_DoCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %s", err)
+	}
+
+	resp, err := c.DoCtx(context.Background(), req)
+	if err != nil {
+		t.Fatalf("failed to DoCtx: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,45 @@
+package xhttp
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// NewInMemoryJar returns an http.CookieJar backed by cookiejar's in-memory
+// implementation, ready to be set as ClientConfig.CookieJar.
+func NewInMemoryJar() http.CookieJar {
+	jar, _ := cookiejar.New(nil)
+
+	return jar
+}
+
+// AddCookie adds a cookie to the request, appending to any Cookie header
+// already set.
+func (r *Request) AddCookie(c *http.Cookie) {
+	if r.Header == nil {
+		r.Header = make(http.Header)
+	}
+
+	s := c.String()
+	if existing := r.Header.Get("Cookie"); existing != "" {
+		s = existing + "; " + s
+	}
+
+	r.Header.Set("Cookie", s)
+}
+
+// Cookies parses the Set-Cookie headers from the response.
+func (r *Response) Cookies() []*http.Cookie {
+	return (&http.Response{Header: r.Headers}).Cookies()
+}
+
+// Cookies returns the cookies stored in the Client's CookieJar for u, or nil
+// if no CookieJar is configured.
+func (c *Client) Cookies(u *url.URL) []*http.Cookie {
+	if c.HTTPClient.Jar == nil {
+		return nil
+	}
+
+	return c.HTTPClient.Jar.Cookies(u)
+}
@@ -0,0 +1,71 @@
+package xhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRequest_AddCookie(t *testing.T) {
+	req := NewRequest(http.MethodGet, "http://localhost", nil)
+	req.AddCookie(&http.Cookie{Name: "a", Value: "1"})
+	req.AddCookie(&http.Cookie{Name: "b", Value: "2"})
+
+	if got := req.Header.Get("Cookie"); got != "a=1; b=2" {
+		t.Errorf("expected 'a=1; b=2', got %q", got)
+	}
+}
+
+func TestResponse_Cookies(t *testing.T) {
+	headers := make(http.Header)
+	headers.Add("Set-Cookie", "a=1")
+	headers.Add("Set-Cookie", "b=2")
+
+	resp := &Response{Headers: headers}
+
+	cookies := resp.Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("expected 2 cookies, got %d", len(cookies))
+	}
+
+	if cookies[0].Name != "a" || cookies[0].Value != "1" {
+		t.Errorf("expected a=1, got %s=%s", cookies[0].Name, cookies[0].Value)
+	}
+}
+
+func TestClient_CookieJar(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultClientConfig()
+	cfg.CookieJar = NewInMemoryJar()
+
+	c := NewClientWithConfig(cfg)
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+
+	cookies := c.Cookies(u)
+	if len(cookies) != 1 || cookies[0].Name != "session" || cookies[0].Value != "abc" {
+		t.Errorf("expected session=abc, got %v", cookies)
+	}
+}
+
+func TestClient_Cookies_NoJar(t *testing.T) {
+	c := NewClient()
+
+	u, _ := url.Parse("http://localhost")
+	if got := c.Cookies(u); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
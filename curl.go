@@ -0,0 +1,57 @@
+package xhttp
+
+import (
+	"log"
+	"strings"
+)
+
+// Logger is the logging interface used by Client when Debug is true. It's
+// satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logger returns c.Logger, defaulting to log.Default() when unset.
+func (c *Client) logger() Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+
+	return log.Default()
+}
+
+// ToCurl renders the request as a shell-safe curl command, useful for
+// reproducing failing requests against a real server.
+func (r *Request) ToCurl() string {
+	url := r.BaseURL
+	if len(r.Param) != 0 {
+		url = strings.Join([]string{r.BaseURL, "?", r.Param.Encode()}, "")
+	}
+
+	var b strings.Builder
+
+	b.WriteString("curl -X ")
+	b.WriteString(shellescape(r.Method))
+
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			b.WriteString(" -H ")
+			b.WriteString(shellescape(k + ": " + v))
+		}
+	}
+
+	if len(r.Body) > 0 {
+		b.WriteString(" -d ")
+		b.WriteString(shellescape(string(r.Body)))
+	}
+
+	b.WriteString(" ")
+	b.WriteString(shellescape(url))
+
+	return b.String()
+}
+
+// shellescape quotes s for safe use as a single POSIX shell argument.
+func shellescape(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,110 @@
+package xhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShellescape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "simple", want: "'simple'"},
+		{in: "it's", want: `'it'\''s'`},
+		{in: "line1\nline2", want: "'line1\nline2'"},
+	}
+
+	for _, tc := range tests {
+		if got := shellescape(tc.in); got != tc.want {
+			t.Errorf("shellescape(%q): expected %q, got %q", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestRequest_ToCurl(t *testing.T) {
+	req := NewRequest(http.MethodPost, "http://localhost/path", []byte("it's a body"))
+	req.Header.Set("X-Test", "value")
+
+	curl := req.ToCurl()
+
+	if !strings.HasPrefix(curl, "curl -X 'POST'") {
+		t.Errorf("expected curl command to start with method, got %q", curl)
+	}
+
+	if !strings.Contains(curl, "-H 'X-Test: value'") {
+		t.Errorf("expected header in curl command, got %q", curl)
+	}
+
+	if !strings.Contains(curl, `-d 'it'\''s a body'`) {
+		t.Errorf("expected escaped body in curl command, got %q", curl)
+	}
+
+	if !strings.HasSuffix(curl, "'http://localhost/path'") {
+		t.Errorf("expected url at the end of curl command, got %q", curl)
+	}
+}
+
+func TestRequest_ToCurl_WithParams(t *testing.T) {
+	req := NewRequest(http.MethodGet, "http://localhost/path", nil)
+	req.Param.Set("q", "1")
+
+	curl := req.ToCurl()
+
+	if !strings.Contains(curl, "q=1") {
+		t.Errorf("expected query params in curl command, got %q", curl)
+	}
+}
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, format)
+}
+
+func TestClient_Debug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	logger := &testLogger{}
+
+	cfg := DefaultClientConfig()
+	cfg.Debug = true
+	cfg.Logger = logger
+
+	c := NewClientWithConfig(cfg)
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+
+	if len(logger.lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %v", len(logger.lines), logger.lines)
+	}
+}
+
+func TestClient_Debug_Disabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	logger := &testLogger{}
+
+	c := NewClient()
+	c.Logger = logger
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+
+	if len(logger.lines) != 0 {
+		t.Errorf("expected no log lines, got %d", len(logger.lines))
+	}
+}
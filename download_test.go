@@ -0,0 +1,221 @@
+package xhttp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClient_Download(t *testing.T) {
+	const content = "the quick brown fox jumps over the lazy dog"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	var gotDone, gotTotal int64
+	opts := &DownloadOptions{
+		Progress: func(done, total int64) {
+			gotDone, gotTotal = done, total
+		},
+	}
+
+	c := NewClient()
+	if err := c.Download(context.Background(), srv.URL, dest, opts); err != nil {
+		t.Fatalf("failed to Download: %s", err)
+	}
+
+	got, err := ioutil.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %s", err)
+	}
+
+	if string(got) != content {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+
+	if gotDone != int64(len(content)) || gotTotal != int64(len(content)) {
+		t.Errorf("expected progress %d/%d, got %d/%d", len(content), len(content), gotDone, gotTotal)
+	}
+
+	if _, err := os.Stat(dest + DefaultTempSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected temp file to be removed, stat err: %v", err)
+	}
+}
+
+func TestClient_Download_ChecksumMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("content"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	c := NewClient()
+	err := c.Download(context.Background(), srv.URL, dest, &DownloadOptions{ExpectedSHA256: "deadbeef"})
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+
+	if _, statErr := os.Stat(dest); !os.IsNotExist(statErr) {
+		t.Errorf("expected destination to not be created on checksum mismatch")
+	}
+}
+
+func TestClient_Download_ChecksumMatch(t *testing.T) {
+	const content = "content"
+	sum := sha256.Sum256([]byte(content))
+	expected := hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	c := NewClient()
+	if err := c.Download(context.Background(), srv.URL, dest, &DownloadOptions{ExpectedSHA256: expected}); err != nil {
+		t.Fatalf("failed to Download: %s", err)
+	}
+}
+
+func TestClient_Download_Resume(t *testing.T) {
+	const full = "0123456789"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		if rng == "" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(full))
+			return
+		}
+
+		w.Header().Set("Content-Range", "bytes 5-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(full[5:]))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	if err := ioutil.WriteFile(dest+DefaultTempSuffix, []byte(full[:5]), 0644); err != nil {
+		t.Fatalf("failed to seed partial file: %s", err)
+	}
+
+	c := NewClient()
+	if err := c.Download(context.Background(), srv.URL, dest, &DownloadOptions{Resume: true}); err != nil {
+		t.Fatalf("failed to Download: %s", err)
+	}
+
+	got, err := ioutil.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %s", err)
+	}
+
+	if string(got) != full {
+		t.Errorf("expected %q, got %q", full, got)
+	}
+}
+
+func TestClient_Download_Header(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected Authorization header, got %q", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("secret"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	header := make(http.Header)
+	header.Set("Authorization", "Bearer token")
+
+	c := NewClient()
+	if err := c.Download(context.Background(), srv.URL, dest, &DownloadOptions{Header: header}); err != nil {
+		t.Fatalf("failed to Download: %s", err)
+	}
+}
+
+func TestClient_Download_RunsMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected middleware to set Authorization, got %q", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("content"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	c := NewClient()
+	c.UseRequest(func(c *Client, req *Request) error {
+		req.SetAuthorization("Bearer token")
+		return nil
+	})
+
+	var seenStatus int
+	c.UseResponse(func(c *Client, resp *Response) error {
+		resp.StatusCode = http.StatusTeapot
+		return nil
+	}, func(c *Client, resp *Response) error {
+		seenStatus = resp.StatusCode
+		return nil
+	})
+
+	if err := c.Download(context.Background(), srv.URL, dest, nil); err != nil {
+		t.Fatalf("failed to Download: %s", err)
+	}
+
+	if seenStatus != http.StatusTeapot {
+		t.Errorf("expected the second middleware to see the first middleware's mutation, got %d", seenStatus)
+	}
+}
+
+func TestClient_DownloadFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("success"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.txt")
+
+	c := NewClient()
+	if err := c.DownloadFile(srv.URL, dest); err != nil {
+		t.Fatalf("failed to DownloadFile: %s", err)
+	}
+
+	got, err := ioutil.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %s", err)
+	}
+
+	if string(got) != "success" {
+		t.Errorf("expected 'success', got %q", got)
+	}
+}
@@ -0,0 +1,122 @@
+package xhttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Codec marshals and unmarshals request/response bodies for SendJSON.
+type Codec interface {
+	// Marshal encodes v into a request body.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data from a response body into v.
+	Unmarshal(data []byte, v interface{}) error
+
+	// ContentType is the value set for the Content-Type and Accept headers.
+	ContentType() string
+}
+
+// JSONCodec is the default Codec, backed by encoding/json.
+type JSONCodec struct{}
+
+// Marshal encodes v as JSON.
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// ContentType returns "application/json".
+func (JSONCodec) ContentType() string {
+	return "application/json"
+}
+
+// APIError is returned by SendJSON for non-2xx responses. It carries the
+// status, headers, and raw body so callers can inspect or decode it.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Headers    http.Header
+	Body       []byte
+
+	codec Codec
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("xhttp: unexpected status %s", e.Status)
+}
+
+// Decode unmarshals the error body into v using the Codec active when the
+// error was returned.
+func (e *APIError) Decode(v interface{}) error {
+	codec := e.codec
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	return codec.Unmarshal(e.Body, v)
+}
+
+// codec returns the Client's Codec, defaulting to JSONCodec when unset.
+func (c *Client) codec() Codec {
+	if c.Codec != nil {
+		return c.Codec
+	}
+
+	return JSONCodec{}
+}
+
+// SendJSON marshals in using the Client's Codec, sends it as method to url,
+// and, for a 2xx response, unmarshals the body into out using the same
+// codec. For a non-2xx response it returns an *APIError instead.
+//
+// Either in or out may be nil to skip marshaling the request body or
+// unmarshaling the response body, respectively.
+//
+// SendJSON goes through Send, so it runs the same RequestMiddleware and
+// ResponseMiddleware chain, retry policy, rate limiting, and Debug/curl
+// logging as every other call on the Client.
+func (c *Client) SendJSON(ctx context.Context, method, url string, in, out interface{}) error {
+	codec := c.codec()
+
+	req := NewRequestWithContext(ctx, method, url, nil)
+	if in != nil {
+		b, err := codec.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		req.Body = b
+	}
+
+	req.SetContentType(codec.ContentType())
+	req.Header.Set("Accept", codec.ContentType())
+
+	resp, err := c.Send(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Headers:    resp.Headers,
+			Body:       resp.Body,
+			codec:      codec,
+		}
+	}
+
+	if out == nil || len(resp.Body) == 0 {
+		return nil
+	}
+
+	return codec.Unmarshal(resp.Body, out)
+}
@@ -0,0 +1,140 @@
+package xhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type jsonPayload struct {
+	Name string `json:"name"`
+}
+
+func TestRequest_SetJSONBody(t *testing.T) {
+	req := NewRequest(http.MethodPost, "http://localhost", nil)
+
+	if err := req.SetJSONBody(jsonPayload{Name: "test"}); err != nil {
+		t.Fatalf("failed to SetJSONBody: %s", err)
+	}
+
+	if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %s", ct)
+	}
+
+	var got jsonPayload
+	if err := json.Unmarshal(req.Body, &got); err != nil {
+		t.Fatalf("failed to unmarshal body: %s", err)
+	}
+
+	if got.Name != "test" {
+		t.Errorf("expected name 'test', got %s", got.Name)
+	}
+}
+
+func TestResponse_JSON(t *testing.T) {
+	resp := &Response{Body: []byte(`{"name":"test"}`)}
+
+	var got jsonPayload
+	if err := resp.JSON(&got); err != nil {
+		t.Fatalf("failed to decode JSON: %s", err)
+	}
+
+	if got.Name != "test" {
+		t.Errorf("expected name 'test', got %s", got.Name)
+	}
+}
+
+func TestClient_SendJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in jsonPayload
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("failed to decode request body: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(jsonPayload{Name: in.Name + "-out"})
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	var out jsonPayload
+	if err := c.SendJSON(context.Background(), http.MethodPost, srv.URL, jsonPayload{Name: "in"}, &out); err != nil {
+		t.Fatalf("failed to SendJSON: %s", err)
+	}
+
+	if out.Name != "in-out" {
+		t.Errorf("expected 'in-out', got %s", out.Name)
+	}
+}
+
+func TestClient_SendJSON_RunsMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected middleware to set Authorization, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(jsonPayload{Name: "ok"})
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	c.UseRequest(func(c *Client, req *Request) error {
+		req.SetAuthorization("Bearer token")
+		return nil
+	})
+
+	var seenStatus int
+	c.UseResponse(func(c *Client, resp *Response) error {
+		seenStatus = resp.StatusCode
+		return nil
+	})
+
+	var out jsonPayload
+	if err := c.SendJSON(context.Background(), http.MethodGet, srv.URL, nil, &out); err != nil {
+		t.Fatalf("failed to SendJSON: %s", err)
+	}
+
+	if seenStatus != http.StatusOK {
+		t.Errorf("expected response middleware to observe 200, got %d", seenStatus)
+	}
+}
+
+func TestClient_SendJSON_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(jsonPayload{Name: "bad"})
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	err := c.SendJSON(context.Background(), http.MethodGet, srv.URL, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", apiErr.StatusCode)
+	}
+
+	var decoded jsonPayload
+	if err := apiErr.Decode(&decoded); err != nil {
+		t.Fatalf("failed to decode APIError body: %s", err)
+	}
+
+	if decoded.Name != "bad" {
+		t.Errorf("expected 'bad', got %s", decoded.Name)
+	}
+}
@@ -0,0 +1,23 @@
+package xhttp
+
+// RequestMiddleware is invoked on a Request before it is sent, in the order
+// middleware was registered with Client.UseRequest. Returning an error
+// aborts Send without making the request.
+type RequestMiddleware func(c *Client, req *Request) error
+
+// ResponseMiddleware is invoked on a Response after it is received, in the
+// order middleware was registered with Client.UseResponse. Returning an
+// error aborts Send and propagates the error instead of the response.
+type ResponseMiddleware func(c *Client, resp *Response) error
+
+// UseRequest registers one or more RequestMiddleware to run, in order,
+// before every request sent via Send.
+func (c *Client) UseRequest(mw ...RequestMiddleware) {
+	c.requestMiddleware = append(c.requestMiddleware, mw...)
+}
+
+// UseResponse registers one or more ResponseMiddleware to run, in order,
+// after every response received via Send.
+func (c *Client) UseResponse(mw ...ResponseMiddleware) {
+	c.responseMiddleware = append(c.responseMiddleware, mw...)
+}
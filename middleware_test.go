@@ -0,0 +1,87 @@
+package xhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_UseRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-From-Middleware"); got != "yes" {
+			t.Errorf("expected middleware to set header, got %q", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	c.UseRequest(func(c *Client, req *Request) error {
+		req.Header.Set("X-From-Middleware", "yes")
+		return nil
+	})
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+}
+
+func TestClient_UseRequest_Abort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request should not have been sent")
+	}))
+	defer srv.Close()
+
+	wantErr := errors.New("blocked")
+
+	c := NewClient()
+	c.UseRequest(func(c *Client, req *Request) error {
+		return wantErr
+	})
+
+	if _, err := c.GET(srv.URL); err != wantErr {
+		t.Errorf("expected %s, got %s", wantErr, err)
+	}
+}
+
+func TestClient_UseResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var seen int
+	c := NewClient()
+	c.UseResponse(func(c *Client, resp *Response) error {
+		seen = resp.StatusCode
+		return nil
+	})
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+
+	if seen != http.StatusOK {
+		t.Errorf("expected middleware to observe %d, got %d", http.StatusOK, seen)
+	}
+}
+
+func TestClient_UseResponse_Abort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	wantErr := errors.New("rejected")
+
+	c := NewClient()
+	c.UseResponse(func(c *Client, resp *Response) error {
+		return wantErr
+	})
+
+	if _, err := c.GET(srv.URL); err != wantErr {
+		t.Errorf("expected %s, got %s", wantErr, err)
+	}
+}
@@ -0,0 +1,197 @@
+package xhttp
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Retry defaults.
+const (
+	DefaultMaxRetries  = 3
+	DefaultBaseBackoff = 200 * time.Millisecond
+	DefaultMaxBackoff  = 5 * time.Second
+)
+
+// RetryPolicy controls how Client retries transient failures in Send and Do.
+//
+// A nil *RetryPolicy on ClientConfig disables retries entirely.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the initial
+	// one.
+	MaxRetries int
+
+	// BaseBackoff is the wait before the first retry; it doubles on every
+	// subsequent attempt, capped at MaxBackoff.
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the computed wait between retries.
+	MaxBackoff time.Duration
+
+	// Jitter randomizes the wait using full jitter, i.e. a random value in
+	// [0, wait], to avoid retry storms across clients.
+	Jitter bool
+
+	// Retryable decides whether a response/error pair should be retried.
+	// It defaults to DefaultRetryable when nil.
+	Retryable func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible retry settings.
+//
+// It is not applied automatically; set it on ClientConfig.RetryPolicy to
+// enable retries.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:  DefaultMaxRetries,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		Jitter:      true,
+		Retryable:   DefaultRetryable,
+	}
+}
+
+// DefaultRetryable reports whether a request should be retried: connection
+// errors, and 5xx or 429 responses.
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryable reports whether resp/err should be retried according to p.
+func (p *RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(resp, err)
+	}
+
+	return DefaultRetryable(resp, err)
+}
+
+// backoff computes the wait before the given attempt (0-indexed). retryAfter,
+// when non-zero, takes precedence over the computed exponential backoff.
+func (p *RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	wait := p.BaseBackoff << uint(attempt)
+	if wait <= 0 || wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter {
+		wait = time.Duration(rand.Int63n(int64(wait) + 1))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses the Retry-After header, which may be either a
+// number of seconds or an HTTP-date. It returns 0 if the header is absent,
+// malformed, or already in the past.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// RateLimiter is a token-bucket limiter used to cap the rate of outbound
+// requests, modeled after the rate limiters in client-go's flowcontrol
+// package.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	qps   float64
+	burst float64
+
+	tokens   float64
+	lastTick time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing qps requests per second, with
+// bursts up to burst.
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		qps:      qps,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastTick: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, whichever comes
+// first. It returns ctx.Err() if ctx is done before a token frees up.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := rl.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, otherwise it
+// returns the wait until one will be.
+func (rl *RateLimiter) reserve() time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(rl.lastTick).Seconds()
+	rl.lastTick = now
+
+	rl.tokens += elapsed * rl.qps
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+
+	if rl.tokens >= 1 {
+		rl.tokens--
+		return 0
+	}
+
+	missing := 1 - rl.tokens
+	rl.tokens = 0
+
+	return time.Duration(missing / rl.qps * float64(time.Second))
+}
@@ -0,0 +1,221 @@
+package xhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{name: "error", err: errors.New("boom"), want: true},
+		{name: "no resp no err", want: false},
+		{name: "429", resp: &http.Response{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "500", resp: &http.Response{StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "200", resp: &http.Response{StatusCode: http.StatusOK}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			if got := DefaultRetryable(tc.resp, tc.err); got != tc.want {
+				tt.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: false}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 100 * time.Millisecond},
+		{attempt: 1, want: 200 * time.Millisecond},
+		{attempt: 2, want: 400 * time.Millisecond},
+		{attempt: 5, want: time.Second},
+	}
+
+	for _, tc := range tests {
+		if got := p.backoff(tc.attempt, 0); got != tc.want {
+			t.Errorf("attempt %d: expected %s, got %s", tc.attempt, tc.want, got)
+		}
+	}
+
+	if got := p.backoff(0, 3*time.Second); got != 3*time.Second {
+		t.Errorf("expected Retry-After to take precedence, got %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	h := make(http.Header)
+	if got := parseRetryAfter(h); got != 0 {
+		t.Errorf("expected 0 for missing header, got %s", got)
+	}
+
+	h.Set("Retry-After", "2")
+	if got := parseRetryAfter(h); got != 2*time.Second {
+		t.Errorf("expected 2s, got %s", got)
+	}
+
+	h.Set("Retry-After", time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat))
+	if got := parseRetryAfter(h); got <= 0 || got > 5*time.Second {
+		t.Errorf("expected a positive duration up to 5s, got %s", got)
+	}
+}
+
+func TestClient_Send_RetriesTransientFailures(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("success"))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultClientConfig()
+	cfg.RetryPolicy = &RetryPolicy{MaxRetries: 3, BaseBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+	c := NewClientWithConfig(cfg)
+
+	resp, err := c.POST(srv.URL, "application/octet-stream", []byte("payload"))
+	if err != nil {
+		t.Fatalf("failed to POST: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestClient_Do_NoRetryWithoutGetBody(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultClientConfig()
+	cfg.RetryPolicy = &RetryPolicy{MaxRetries: 3, BaseBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+	c := NewClientWithConfig(cfg)
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader([]byte("payload")))
+	if err != nil {
+		t.Fatalf("failed to build request: %s", err)
+	}
+	req.GetBody = nil
+
+	if _, err := c.Do(req); err != nil {
+		t.Fatalf("failed to Do: %s", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call since the body can't be replayed, got %d", got)
+	}
+}
+
+func TestClient_Send_RetryHonorsContextCancellation(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultClientConfig()
+	cfg.RetryPolicy = &RetryPolicy{MaxRetries: 5, BaseBackoff: 2 * time.Second, MaxBackoff: 2 * time.Second}
+
+	c := NewClientWithConfig(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	req := NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := c.SendCtx(ctx, req); err == nil {
+		t.Fatal("expected an error from a cancelled context")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the backoff wait to be cut short by ctx, took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	rl := NewRateLimiter(1000, 1)
+
+	// The first call consumes the initial burst token immediately.
+	if err := rl.Wait(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	// The second call has to wait for a token to refill, but well under a
+	// second at 1000 qps.
+	start := time.Now()
+	if err := rl.Wait(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Wait to return quickly, took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_ContextCancelled(t *testing.T) {
+	rl := NewRateLimiter(0.001, 1)
+
+	// Drain the single burst token so the next Wait has to block.
+	rl.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := rl.Wait(ctx); err != ctx.Err() {
+		t.Errorf("expected %s, got %s", ctx.Err(), err)
+	}
+}
+
+func TestClient_Send_NoRetryWithoutPolicy(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+
+	if _, err := c.GET(srv.URL); err != nil {
+		t.Fatalf("failed to GET: %s", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
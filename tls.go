@@ -0,0 +1,38 @@
+package xhttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// newTLSConfig builds the tls.Config used by the Client's transport from
+// cfg, including the root CA pool and mTLS client certificates.
+func newTLSConfig(cfg *ClientConfig) *tls.Config {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.SkipTLSVerify,
+		Certificates:       cfg.ClientCertificates,
+		ServerName:         cfg.ServerName,
+		MinVersion:         cfg.MinTLSVersion,
+	}
+
+	if cfg.IncludeRootCA {
+		tlsConfig.RootCAs = newRootCAPool(cfg.RootCAs)
+	}
+
+	return tlsConfig
+}
+
+// newRootCAPool returns the system cert pool (or a fresh one if it can't be
+// loaded) with the given PEM-encoded certificates appended.
+func newRootCAPool(rootCAs [][]byte) *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, pem := range rootCAs {
+		pool.AppendCertsFromPEM(pem)
+	}
+
+	return pool
+}
@@ -0,0 +1,61 @@
+package xhttp
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	cfg := DefaultClientConfig()
+	cfg.SkipTLSVerify = true
+	cfg.ServerName = "example.com"
+	cfg.MinTLSVersion = tls.VersionTLS12
+
+	tlsConfig := newTLSConfig(cfg)
+
+	if !tlsConfig.InsecureSkipVerify {
+		t.Error("expected InsecureSkipVerify to be true")
+	}
+
+	if tlsConfig.ServerName != "example.com" {
+		t.Errorf("expected ServerName example.com, got %s", tlsConfig.ServerName)
+	}
+
+	if tlsConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected MinVersion %d, got %d", tls.VersionTLS12, tlsConfig.MinVersion)
+	}
+
+	if tlsConfig.RootCAs != nil {
+		t.Error("expected no RootCAs when IncludeRootCA is false")
+	}
+}
+
+func TestNewTLSConfig_IncludeRootCA(t *testing.T) {
+	cfg := DefaultClientConfig()
+	cfg.IncludeRootCA = true
+
+	tlsConfig := newTLSConfig(cfg)
+
+	if tlsConfig.RootCAs == nil {
+		t.Error("expected a RootCAs pool when IncludeRootCA is true")
+	}
+}
+
+func TestNewClientWithConfig_TLS(t *testing.T) {
+	cfg := DefaultClientConfig()
+	cfg.SkipTLSVerify = true
+
+	c := NewClientWithConfig(cfg)
+
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", c.HTTPClient.Transport)
+	}
+
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected InsecureSkipVerify to propagate to the transport")
+	}
+
+	testClientGet(t, c)
+}
@@ -3,8 +3,9 @@ package xhttp
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
-	"fmt"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net"
@@ -87,11 +88,68 @@ type ClientConfig struct {
 	MaxIdleConns          int
 	SkipTLSVerify         bool
 	IncludeRootCA         bool
+
+	// RootCAs are PEM-encoded certificates appended to the pool used to
+	// verify server certificates. They're only used when IncludeRootCA is
+	// true; the pool otherwise starts from x509.SystemCertPool().
+	RootCAs [][]byte
+
+	// ClientCertificates are presented to the server for mutual TLS.
+	ClientCertificates []tls.Certificate
+
+	// ServerName overrides the server name used for SNI and certificate
+	// verification; it defaults to the host from the request URL.
+	ServerName string
+
+	// MinTLSVersion sets the minimum accepted TLS version, e.g.
+	// tls.VersionTLS12. Zero uses crypto/tls's default.
+	MinTLSVersion uint16
+
+	// CookieJar, when set, is used to persist cookies across requests. Use
+	// NewInMemoryJar for a ready-to-use in-memory jar.
+	CookieJar http.CookieJar
+
+	// Debug, when true, makes Send log the curl equivalent of every request
+	// and a summary of its response via Logger.
+	Debug bool
+
+	// Logger receives debug output when Debug is true. It defaults to
+	// log.Default() when nil.
+	Logger Logger
+
+	// RetryPolicy configures automatic retries of transient failures in
+	// Send and Do. A nil RetryPolicy disables retries.
+	RetryPolicy *RetryPolicy
+
+	// RateLimitQPS caps the number of requests per second Send and Do are
+	// allowed to make, with bursts up to RateLimitBurst. A RateLimitQPS of
+	// 0 disables rate limiting.
+	RateLimitQPS   float64
+	RateLimitBurst int
 }
 
 // Client represents a custom http client wrapper around net/http.Client.
 type Client struct {
 	HTTPClient http.Client
+
+	// Codec marshals and unmarshals bodies for SendJSON. It defaults to
+	// JSONCodec and can be replaced to support other formats, e.g. XML,
+	// protobuf, or msgpack.
+	Codec Codec
+
+	// Debug, when true, makes Send log the curl equivalent of every request
+	// and a summary of its response via Logger.
+	Debug bool
+
+	// Logger receives debug output when Debug is true. It defaults to
+	// log.Default() when nil.
+	Logger Logger
+
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+
+	requestMiddleware  []RequestMiddleware
+	responseMiddleware []ResponseMiddleware
 }
 
 // NewClient returns a Client with customized default settings.
@@ -109,12 +167,7 @@ func NewClientWithConfig(cfg *ClientConfig) *Client {
 // createClient creates a new Client using custom tls.Config and http.Transport.
 func createClient(cfg *ClientConfig) *Client {
 	// Create a custom tls config.
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: cfg.SkipTLSVerify,
-	}
-
-	// TODO: Create a pool with root CA.
-	// if customCACerts {}
+	tlsConfig := newTLSConfig(cfg)
 
 	// Create a custom transport.
 	transport := &http.Transport{
@@ -135,7 +188,16 @@ func createClient(cfg *ClientConfig) *Client {
 		HTTPClient: http.Client{
 			Timeout:   cfg.Timeout,
 			Transport: transport,
+			Jar:       cfg.CookieJar,
 		},
+		Codec:       JSONCodec{},
+		Debug:       cfg.Debug,
+		Logger:      cfg.Logger,
+		retryPolicy: cfg.RetryPolicy,
+	}
+
+	if cfg.RateLimitQPS > 0 {
+		client.rateLimiter = NewRateLimiter(cfg.RateLimitQPS, cfg.RateLimitBurst)
 	}
 
 	return client
@@ -153,12 +215,96 @@ func DefaultClientConfig() *ClientConfig {
 		MaxIdleConns:          DefaultMaxIdleConns,
 		SkipTLSVerify:         false,
 		IncludeRootCA:         false,
+		RootCAs:               nil,
+		ClientCertificates:    nil,
+		ServerName:            "",
+		MinTLSVersion:         0,
+		CookieJar:             nil,
+		Debug:                 false,
+		Logger:                nil,
+		RetryPolicy:           nil,
+		RateLimitQPS:          0,
+		RateLimitBurst:        0,
 	}
 }
 
 // Do performs a request based on http.Request.
+//
+// If a RetryPolicy is configured on the Client, transient failures are
+// retried according to it; the request body is replayed via req.GetBody
+// when available. A request with a body but no GetBody is attempted at most
+// once.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.HTTPClient.Do(req)
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.retryPolicy == nil {
+		return c.HTTPClient.Do(req)
+	}
+
+	return c.doWithRetry(req)
+}
+
+// doWithRetry performs req, retrying according to c.retryPolicy until it
+// succeeds, is exhausted, or the failure is not retryable.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	for attempt := 0; ; attempt++ {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		resp, err = c.HTTPClient.Do(req)
+
+		retry := attempt < policy.MaxRetries && policy.retryable(resp, err)
+		if retry && req.GetBody == nil && req.Body != nil && req.Body != http.NoBody {
+			retry = false
+		}
+
+		if !retry {
+			return resp, err
+		}
+
+		var retryAfter time.Duration
+		if resp != nil {
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			retryAfter = parseRetryAfter(resp.Header)
+		}
+
+		if req.GetBody != nil {
+			body, gbErr := req.GetBody()
+			if gbErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		if c.rateLimiter != nil {
+			if werr := c.rateLimiter.Wait(req.Context()); werr != nil {
+				return nil, werr
+			}
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt, retryAfter))
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
 }
 
 // Get makes a Get request.
@@ -202,7 +348,22 @@ func (c *Client) POST(url, contentType string, body []byte) (*Response, error) {
 }
 
 // Send makes a request.
+//
+// Before the request is built, every RequestMiddleware registered via
+// UseRequest runs, in order; after the response is built, every
+// ResponseMiddleware registered via UseResponse runs, in order. Either can
+// abort the call by returning an error.
 func (c *Client) Send(request *Request) (*Response, error) {
+	for _, mw := range c.requestMiddleware {
+		if err := mw(c, request); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Debug {
+		c.logger().Printf("xhttp: %s", request.ToCurl())
+	}
+
 	// Build the HTTP request object.
 	req, err := c.buildRequest(request)
 	if err != nil {
@@ -219,21 +380,29 @@ func (c *Client) Send(request *Request) (*Response, error) {
 		return nil, err
 	}
 
-	return c.buildResponse(resp)
-}
-
-// DownloadFile downloads the file located at an url and stores it in the given path.
-func (c *Client) DownloadFile(url, filename string) error {
-	resp, err := c.GET(url)
+	response, err := c.buildResponse(resp)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if c.Debug {
+		c.logger().Printf("xhttp: response %s, %d bytes", response.Status, len(response.Body))
 	}
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("download failed: %s", resp.Status)
+	for _, mw := range c.responseMiddleware {
+		if err := mw(c, response); err != nil {
+			return nil, err
+		}
 	}
 
-	return ioutil.WriteFile(filename, resp.Body, 0644)
+	return response, nil
+}
+
+// DownloadFile downloads the file located at an url and stores it in the
+// given path, streaming the body directly to disk. See Download for
+// progress reporting, resume, and checksum verification.
+func (c *Client) DownloadFile(url, filename string) error {
+	return c.Download(context.Background(), url, filename, nil)
 }
 
 // buildRequest creates a http.Request from Request.
@@ -243,7 +412,7 @@ func (c *Client) buildRequest(req *Request) (*http.Request, error) {
 		url = strings.Join([]string{req.BaseURL, "?", req.Param.Encode()}, "")
 	}
 
-	r, err := http.NewRequest(req.Method, url, bytes.NewBuffer(req.Body))
+	r, err := http.NewRequestWithContext(req.Context(), req.Method, url, bytes.NewBuffer(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -281,17 +450,51 @@ type Request struct {
 	Body    []byte
 	Header  http.Header
 	Param   url.Values
+
+	ctx context.Context
 }
 
 // NewRequest returns a Request ready for use.
 func NewRequest(m string, u string, b []byte) *Request {
+	return NewRequestWithContext(context.Background(), m, u, b)
+}
+
+// NewRequestWithContext returns a Request ready for use, bound to ctx for
+// cancellation and deadlines independent of the Client's Timeout.
+func NewRequestWithContext(ctx context.Context, m string, u string, b []byte) *Request {
 	return &Request{
 		Method:  m,
 		BaseURL: u,
 		Body:    b,
 		Header:  make(http.Header),
 		Param:   make(url.Values),
+		ctx:     ctx,
+	}
+}
+
+// Context returns the Request's context, or context.Background() if none
+// was set, e.g. when the Request was built as a struct literal rather than
+// via NewRequest.
+func (r *Request) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
 	}
+
+	return context.Background()
+}
+
+// WithContext returns a shallow copy of r with its context changed to ctx.
+// ctx must be non-nil.
+func (r *Request) WithContext(ctx context.Context) *Request {
+	if ctx == nil {
+		panic("xhttp: nil context")
+	}
+
+	r2 := new(Request)
+	*r2 = *r
+	r2.ctx = ctx
+
+	return r2
 }
 
 // SetContentTypeJSON sets the Content-Type header to "application/json".
@@ -321,6 +524,20 @@ func (r *Request) SetAuthorization(value string) {
 	r.Header.Set("Authorization", value)
 }
 
+// SetJSONBody marshals v as JSON, sets it as the request body, and sets the
+// Content-Type header to "application/json".
+func (r *Request) SetJSONBody(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	r.Body = b
+	r.SetContentTypeJSON()
+
+	return nil
+}
+
 // Response holds data from a response.
 type Response struct {
 	StatusCode int
@@ -328,3 +545,8 @@ type Response struct {
 	Body       []byte
 	Headers    http.Header
 }
+
+// JSON unmarshals the response body as JSON into v.
+func (r *Response) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}